@@ -0,0 +1,128 @@
+package main
+
+import (
+	"encoding/xml"
+	"net/http"
+	"net/url"
+	"time"
+
+	"myBolg/internal/feed"
+)
+
+func (s *Site) handleAtom(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/atom.xml" {
+		http.NotFound(w, r)
+		return
+	}
+
+	posts, err := s.Posts()
+	if err != nil {
+		http.Error(w, "读取文章失败", http.StatusInternalServerError)
+		return
+	}
+
+	doc, err := s.buildAtomFeed(posts)
+	if err != nil {
+		http.Error(w, "渲染失败", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/atom+xml; charset=utf-8")
+	_, _ = w.Write(renderAtom(doc))
+}
+
+// buildAtomFeed assembles the Atom document for posts, rendering each
+// post's full HTML body for its <content> element.
+func (s *Site) buildAtomFeed(posts []post) (feed.Atom, error) {
+	baseURL := s.Config.Site.BaseURL
+	host := feedHost(baseURL)
+	doc := feed.Atom{
+		Title:   s.Config.Site.Title,
+		ID:      "tag:" + host + ",2024:/",
+		Updated: latestUpdate(posts),
+		Author:  feed.AtomAuthor{Name: feedAuthor(s.Config)},
+		Links: []feed.AtomLink{
+			{Href: baseURL + "/atom.xml", Rel: "self", Type: "application/atom+xml"},
+			{Href: baseURL + "/", Rel: "alternate", Type: "text/html"},
+		},
+	}
+
+	for _, p := range posts {
+		_, body, err := s.renderPostBySlug(p.Slug)
+		if err != nil {
+			return feed.Atom{}, err
+		}
+
+		entry := feed.AtomEntry{
+			ID:      "tag:" + host + "," + tagYear(p) + ":/post/" + p.Slug,
+			Title:   p.Title,
+			Updated: coalesceTime(p.ModTime, p.Date),
+			Links: []feed.AtomLink{
+				{Href: baseURL + "/post/" + p.Slug, Rel: "alternate", Type: "text/html"},
+			},
+			Content: feed.AtomContent{Type: "html", Body: string(body)},
+		}
+		for _, tag := range p.Tags {
+			entry.Categories = append(entry.Categories, feed.AtomCategory{Term: tag})
+		}
+		doc.Entries = append(doc.Entries, entry)
+	}
+
+	return doc, nil
+}
+
+func renderAtom(doc feed.Atom) []byte {
+	b, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil
+	}
+	return append([]byte(xml.Header), b...)
+}
+
+// feedAuthor returns cfg.Site.Author, falling back to the site title so
+// atom:author (required by RFC 4287 §4.1.1) is not emitted empty unless
+// both are empty.
+func feedAuthor(cfg Config) string {
+	if cfg.Site.Author != "" {
+		return cfg.Site.Author
+	}
+	return cfg.Site.Title
+}
+
+func feedHost(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Host == "" {
+		return "localhost"
+	}
+	return parsed.Host
+}
+
+func tagYear(p post) string {
+	if t := coalesceTime(p.Date, p.ModTime); !t.IsZero() {
+		return t.Format("2006")
+	}
+	return time.Now().Format("2006")
+}
+
+func coalesceTime(times ...time.Time) time.Time {
+	for _, t := range times {
+		if !t.IsZero() {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func latestUpdate(posts []post) time.Time {
+	var latest time.Time
+	for _, p := range posts {
+		t := coalesceTime(p.ModTime, p.Date)
+		if t.After(latest) {
+			latest = t
+		}
+	}
+	if latest.IsZero() {
+		latest = time.Now()
+	}
+	return latest
+}
@@ -0,0 +1,36 @@
+package main
+
+import "testing"
+
+func TestBuildAtomFeedIncludesAuthor(t *testing.T) {
+	s := NewSite("posts", false, Config{Site: SiteConfig{Title: "博客", Author: "Jane Doe", BaseURL: "http://example.com"}})
+	doc, err := s.buildAtomFeed(nil)
+	if err != nil {
+		t.Fatalf("buildAtomFeed: %v", err)
+	}
+	if doc.Author.Name != "Jane Doe" {
+		t.Errorf("Author.Name = %q, want %q", doc.Author.Name, "Jane Doe")
+	}
+}
+
+func TestBuildAtomFeedFallsBackToTitle(t *testing.T) {
+	s := NewSite("posts", false, Config{Site: SiteConfig{Title: "博客", BaseURL: "http://example.com"}})
+	doc, err := s.buildAtomFeed(nil)
+	if err != nil {
+		t.Fatalf("buildAtomFeed: %v", err)
+	}
+	if doc.Author.Name != "博客" {
+		t.Errorf("Author.Name = %q, want fallback to site title %q", doc.Author.Name, "博客")
+	}
+}
+
+func TestBuildJSONFeedIncludesAuthor(t *testing.T) {
+	s := NewSite("posts", false, Config{Site: SiteConfig{Title: "博客", Author: "Jane Doe", BaseURL: "http://example.com"}})
+	doc, err := s.buildJSONFeed(nil)
+	if err != nil {
+		t.Fatalf("buildJSONFeed: %v", err)
+	}
+	if len(doc.Authors) != 1 || doc.Authors[0].Name != "Jane Doe" {
+		t.Errorf("Authors = %+v, want a single author named %q", doc.Authors, "Jane Doe")
+	}
+}
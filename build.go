@@ -0,0 +1,126 @@
+package main
+
+import (
+	"encoding/json"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// Build renders the site through the same functions used by the HTTP
+// handlers and writes the resulting static tree to outDir, copying
+// staticDir alongside it if present.
+func Build(s *Site, outDir, staticDir string) error {
+	if err := os.MkdirAll(outDir, 0o755); err != nil {
+		return err
+	}
+
+	posts, err := s.Posts()
+	if err != nil {
+		return err
+	}
+
+	indexPage, err := renderPage("首页", renderIndexContent(posts))
+	if err != nil {
+		return err
+	}
+	if err := writeFile(filepath.Join(outDir, "index.html"), indexPage); err != nil {
+		return err
+	}
+
+	for _, p := range posts {
+		full, body, err := s.renderPostBySlug(p.Slug)
+		if err != nil {
+			return err
+		}
+		page, err := renderPage(full.Title, renderPostContent(full, body))
+		if err != nil {
+			return err
+		}
+		postDir := filepath.Join(outDir, "post", p.Slug)
+		if err := os.MkdirAll(postDir, 0o755); err != nil {
+			return err
+		}
+
+		// Copy any files kept alongside the post's markdown (in
+		// postsDir/<slug>/) so images rewritten to "/post/<slug>/<file>"
+		// resolve in the built output too. Done before writing index.html
+		// so a same-named asset can never clobber the rendered page.
+		assetsDir := filepath.Join(s.PostsDir, p.Slug)
+		if info, err := os.Stat(assetsDir); err == nil && info.IsDir() {
+			if err := copyDir(assetsDir, postDir); err != nil {
+				return err
+			}
+		}
+
+		if err := writeFile(filepath.Join(postDir, "index.html"), page); err != nil {
+			return err
+		}
+	}
+
+	idx := tagIndex(posts)
+	tags := sortedTags(idx)
+	if err := buildTagPages(idx, tags, outDir); err != nil {
+		return err
+	}
+
+	if err := writeFile(filepath.Join(outDir, "rss.xml"), renderRSS(posts, s.Config)); err != nil {
+		return err
+	}
+	if err := s.buildSitemap(posts, outDir); err != nil {
+		return err
+	}
+
+	atomDoc, err := s.buildAtomFeed(posts)
+	if err != nil {
+		return err
+	}
+	if err := writeFile(filepath.Join(outDir, "atom.xml"), renderAtom(atomDoc)); err != nil {
+		return err
+	}
+
+	jsonDoc, err := s.buildJSONFeed(posts)
+	if err != nil {
+		return err
+	}
+	jsonBytes, err := json.MarshalIndent(jsonDoc, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeFile(filepath.Join(outDir, "feed.json"), jsonBytes); err != nil {
+		return err
+	}
+
+	if info, err := os.Stat(staticDir); err == nil && info.IsDir() {
+		if err := copyDir(staticDir, filepath.Join(outDir, "static")); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeFile(path string, data []byte) error {
+	return os.WriteFile(path, data, 0o644)
+}
+
+func copyDir(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if d.IsDir() {
+			return os.MkdirAll(target, 0o755)
+		}
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(target, b, 0o644)
+	})
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Config is the site's config.toml, controlling the site's public
+// identity, the HTTP server, and its security headers.
+type Config struct {
+	Site    SiteConfig    `toml:"site"`
+	Server  ServerConfig  `toml:"server"`
+	Headers HeadersConfig `toml:"headers"`
+}
+
+// SiteConfig is the [site] table.
+type SiteConfig struct {
+	BaseURL     string `toml:"base_url"`
+	Title       string `toml:"title"`
+	Description string `toml:"description"`
+	Language    string `toml:"language"`
+	Author      string `toml:"author"`
+}
+
+// ServerConfig is the [server] table.
+type ServerConfig struct {
+	Listen      string        `toml:"listen"`
+	ReadTimeout time.Duration `toml:"read_timeout"`
+}
+
+// HeadersConfig is the [headers] table.
+type HeadersConfig struct {
+	CSP CSPConfig `toml:"csp"`
+	// HSTS is the Strict-Transport-Security header value. Left empty, the
+	// header is omitted (e.g. for plain-HTTP local development).
+	HSTS string `toml:"hsts"`
+}
+
+// CSPConfig is the [headers.csp] table: one source list per directive.
+type CSPConfig struct {
+	DefaultSrc []string `toml:"default_src"`
+	ScriptSrc  []string `toml:"script_src"`
+	StyleSrc   []string `toml:"style_src"`
+	ImgSrc     []string `toml:"img_src"`
+	ConnectSrc []string `toml:"connect_src"`
+}
+
+// String builds the Content-Security-Policy header value from the
+// configured directives, omitting any that are empty.
+func (c CSPConfig) String() string {
+	var directives []string
+	add := func(name string, values []string) {
+		if len(values) == 0 {
+			return
+		}
+		directives = append(directives, name+" "+strings.Join(values, " "))
+	}
+	add("default-src", c.DefaultSrc)
+	add("script-src", c.ScriptSrc)
+	add("style-src", c.StyleSrc)
+	add("img-src", c.ImgSrc)
+	add("connect-src", c.ConnectSrc)
+	return strings.Join(directives, "; ")
+}
+
+// defaultConfig is used for any table or field left out of config.toml,
+// and as the whole config when the file does not exist.
+func defaultConfig() Config {
+	return Config{
+		Site: SiteConfig{
+			BaseURL:     "http://localhost:8080",
+			Title:       "博客",
+			Description: "个人博客",
+			Language:    "zh-CN",
+		},
+		Server: ServerConfig{
+			Listen:      ":8080",
+			ReadTimeout: 10 * time.Second,
+		},
+		Headers: HeadersConfig{
+			CSP: CSPConfig{
+				DefaultSrc: []string{"'self'"},
+				ScriptSrc:  []string{"'self'"},
+				StyleSrc:   []string{"'self'", "'unsafe-inline'"},
+				ImgSrc:     []string{"'self'", "data:"},
+				ConnectSrc: []string{"'self'"},
+			},
+		},
+	}
+}
+
+// loadConfig reads path into a Config seeded with defaultConfig, so any
+// table or field the file omits keeps its default. A missing file is not
+// an error: the defaults are used as-is.
+func loadConfig(path string) (Config, error) {
+	cfg := defaultConfig()
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return cfg, nil
+	}
+	if _, err := toml.DecodeFile(path, &cfg); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+// withDevReload returns c with 'unsafe-inline' added to script-src, so the
+// inline <script> devReload injects into every page still runs under a
+// strict default script-src. Used only when -dev is active: it weakens the
+// policy, but live-reload's refresh-on-save is a local dev tool, not part
+// of what a deployed build serves.
+func (c CSPConfig) withDevReload() CSPConfig {
+	for _, v := range c.ScriptSrc {
+		if v == "'unsafe-inline'" {
+			return c
+		}
+	}
+	c.ScriptSrc = append(append([]string{}, c.ScriptSrc...), "'unsafe-inline'")
+	return c
+}
+
+// securityHeaders wraps next, setting the Content-Security-Policy header
+// built from cfg plus the site's other standard security headers.
+func securityHeaders(cfg Config, next http.Handler) http.Handler {
+	cspConfig := cfg.Headers.CSP
+	if devMode {
+		cspConfig = cspConfig.withDevReload()
+	}
+	csp := cspConfig.String()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if csp != "" {
+			w.Header().Set("Content-Security-Policy", csp)
+		}
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.Header().Set("Referrer-Policy", "strict-origin-when-cross-origin")
+		if cfg.Headers.HSTS != "" {
+			w.Header().Set("Strict-Transport-Security", cfg.Headers.HSTS)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// devMode is set from the -dev flag and controls whether renderPage
+// injects the live-reload script into every page.
+var devMode bool
+
+// devReloader tracks connected /_reload SSE clients and wakes them up
+// whenever a watched file changes.
+type devReloader struct {
+	mu      sync.Mutex
+	clients map[chan struct{}]struct{}
+}
+
+func newDevReloader() *devReloader {
+	return &devReloader{clients: make(map[chan struct{}]struct{})}
+}
+
+func (d *devReloader) subscribe() chan struct{} {
+	ch := make(chan struct{}, 1)
+	d.mu.Lock()
+	d.clients[ch] = struct{}{}
+	d.mu.Unlock()
+	return ch
+}
+
+func (d *devReloader) unsubscribe(ch chan struct{}) {
+	d.mu.Lock()
+	delete(d.clients, ch)
+	d.mu.Unlock()
+}
+
+func (d *devReloader) broadcast() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for ch := range d.clients {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// handleSSE serves /_reload, streaming a "reload" message to the client
+// whenever a watched file changes.
+func (d *devReloader) handleSSE(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	ch := d.subscribe()
+	defer d.unsubscribe(ch)
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ch:
+			fmt.Fprint(w, "data: reload\n\n")
+			flusher.Flush()
+		}
+	}
+}
+
+// watchForChanges watches dirs for filesystem events, invalidating site's
+// post cache and notifying connected dev clients on every change. It
+// returns once the watcher is set up; events are handled in a goroutine
+// that exits when ctx is done.
+func watchForChanges(ctx context.Context, site *Site, reloader *devReloader, dirs ...string) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+
+	for _, dir := range dirs {
+		if dir == "" {
+			continue
+		}
+		if err := watcher.Add(dir); err != nil {
+			log.Printf("dev: failed to watch %s: %v", dir, err)
+		}
+	}
+
+	go func() {
+		defer watcher.Close()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Remove|fsnotify.Rename) == 0 {
+					continue
+				}
+				site.InvalidatePosts()
+				reloader.broadcast()
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Printf("dev: watcher error: %v", err)
+			}
+		}
+	}()
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// frontMatter is the typed metadata block at the top of a post, decoded
+// from either a YAML ("---") or TOML ("+++") fence.
+type frontMatter struct {
+	Title       string   `yaml:"title" toml:"title"`
+	Date        string   `yaml:"date" toml:"date"`
+	Description string   `yaml:"description" toml:"description"`
+	Draft       bool     `yaml:"draft" toml:"draft"`
+	Aliases     []string `yaml:"aliases" toml:"aliases"`
+	Taxonomies  struct {
+		Tags []string `yaml:"tags" toml:"tags"`
+	} `yaml:"taxonomies" toml:"taxonomies"`
+}
+
+// date parses Date using the layouts commonly seen in front matter,
+// returning the zero time if Date is empty or unparsable.
+func (fm frontMatter) date() time.Time {
+	for _, layout := range []string{"2006-01-02", time.RFC3339, "2006-01-02T15:04:05"} {
+		if t, err := time.Parse(layout, fm.Date); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// parseFrontMatter splits mdText into its front matter and body, decoding
+// a leading "---" (YAML) or "+++" (TOML) fence into a frontMatter value.
+// Text without a recognized fence is returned unchanged as the body.
+func parseFrontMatter(mdText string) (frontMatter, string) {
+	var fm frontMatter
+
+	lines := strings.Split(mdText, "\n")
+	if len(lines) == 0 {
+		return fm, mdText
+	}
+
+	fence := strings.TrimSpace(lines[0])
+	if fence != "---" && fence != "+++" {
+		return fm, mdText
+	}
+
+	end := -1
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == fence {
+			end = i
+			break
+		}
+	}
+	if end == -1 {
+		return fm, mdText
+	}
+
+	raw := strings.Join(lines[1:end], "\n")
+	body := strings.Join(lines[end+1:], "\n")
+
+	switch fence {
+	case "---":
+		_ = yaml.Unmarshal([]byte(raw), &fm)
+	case "+++":
+		_, _ = toml.Decode(raw, &fm)
+	}
+
+	return fm, body
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseFrontMatter(t *testing.T) {
+	cases := []struct {
+		name     string
+		mdText   string
+		wantFM   frontMatter
+		wantBody string
+	}{
+		{
+			name: "yaml fence",
+			mdText: "---\n" +
+				"title: Hello\n" +
+				"date: 2024-01-02\n" +
+				"taxonomies:\n" +
+				"  tags: [\"Go\", \"博客\"]\n" +
+				"---\n" +
+				"body text",
+			wantFM: frontMatter{
+				Title: "Hello",
+				Date:  "2024-01-02",
+				Taxonomies: struct {
+					Tags []string `yaml:"tags" toml:"tags"`
+				}{Tags: []string{"Go", "博客"}},
+			},
+			wantBody: "body text",
+		},
+		{
+			name: "toml fence",
+			mdText: "+++\n" +
+				"title = \"Hello\"\n" +
+				"date = \"2024-01-02\"\n" +
+				"[taxonomies]\n" +
+				"tags = [\"Go\", \"博客\"]\n" +
+				"+++\n" +
+				"body text",
+			wantFM: frontMatter{
+				Title: "Hello",
+				Date:  "2024-01-02",
+				Taxonomies: struct {
+					Tags []string `yaml:"tags" toml:"tags"`
+				}{Tags: []string{"Go", "博客"}},
+			},
+			wantBody: "body text",
+		},
+		{
+			name:     "no fence",
+			mdText:   "# Hello\nbody text",
+			wantFM:   frontMatter{},
+			wantBody: "# Hello\nbody text",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fm, body := parseFrontMatter(tc.mdText)
+			if !reflect.DeepEqual(fm, tc.wantFM) {
+				t.Errorf("fm = %+v, want %+v", fm, tc.wantFM)
+			}
+			if body != tc.wantBody {
+				t.Errorf("body = %q, want %q", body, tc.wantBody)
+			}
+		})
+	}
+}
+
+func TestFrontMatterDate(t *testing.T) {
+	cases := []struct {
+		date string
+		want time.Time
+	}{
+		{"2024-01-02", time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{"2024-01-02T15:04:05Z", time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)},
+		{"", time.Time{}},
+		{"not a date", time.Time{}},
+	}
+
+	for _, tc := range cases {
+		fm := frontMatter{Date: tc.date}
+		if got := fm.date(); !got.Equal(tc.want) {
+			t.Errorf("frontMatter{Date: %q}.date() = %v, want %v", tc.date, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+// Package feed provides typed Atom 1.0 and JSON Feed 1.1 document models,
+// serialized via encoding/xml and encoding/json instead of string
+// concatenation.
+package feed
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// Atom is the root <feed> element of an Atom 1.0 document. RFC 4287
+// §4.1.1 requires atom:author on the feed unless every entry carries its
+// own, so Author must always be populated with a non-empty name.
+type Atom struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Updated time.Time   `xml:"updated"`
+	Author  AtomAuthor  `xml:"author"`
+	Links   []AtomLink  `xml:"link"`
+	Entries []AtomEntry `xml:"entry"`
+}
+
+// AtomAuthor is an Atom <author> element.
+type AtomAuthor struct {
+	Name string `xml:"name"`
+}
+
+// AtomLink is an Atom <link> element.
+type AtomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+// AtomEntry is a single Atom <entry> element.
+type AtomEntry struct {
+	ID         string         `xml:"id"`
+	Title      string         `xml:"title"`
+	Updated    time.Time      `xml:"updated"`
+	Links      []AtomLink     `xml:"link"`
+	Content    AtomContent    `xml:"content"`
+	Categories []AtomCategory `xml:"category,omitempty"`
+}
+
+// AtomContent is an Atom <content> element. Body holds the full rendered
+// HTML; encoding/xml escapes it as character data, matching type="html".
+type AtomContent struct {
+	Type string `xml:"type,attr"`
+	Body string `xml:",chardata"`
+}
+
+// AtomCategory is an Atom <category> element.
+type AtomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+// JSONFeed is a JSON Feed 1.1 document (https://jsonfeed.org/version/1.1).
+type JSONFeed struct {
+	Version     string           `json:"version"`
+	Title       string           `json:"title"`
+	HomePageURL string           `json:"home_page_url"`
+	FeedURL     string           `json:"feed_url"`
+	Authors     []JSONFeedAuthor `json:"authors,omitempty"`
+	Items       []JSONFeedItem   `json:"items"`
+}
+
+// JSONFeedAuthor is a JSON Feed 1.1 author object.
+type JSONFeedAuthor struct {
+	Name string `json:"name"`
+}
+
+// JSONFeedItem is a single entry in JSONFeed.Items.
+type JSONFeedItem struct {
+	ID            string    `json:"id"`
+	URL           string    `json:"url"`
+	Title         string    `json:"title"`
+	ContentHTML   string    `json:"content_html"`
+	DatePublished time.Time `json:"date_published"`
+	DateModified  time.Time `json:"date_modified"`
+	Tags          []string  `json:"tags,omitempty"`
+}
@@ -0,0 +1,59 @@
+// Package sitemap provides typed sitemap 0.9 document models, including
+// the Google image sitemap extension and a sitemap-index for splitting
+// large sites, serialized via encoding/xml instead of string
+// concatenation.
+package sitemap
+
+import "encoding/xml"
+
+// URLSet is the root <urlset> element of a sitemap document.
+type URLSet struct {
+	XMLName    xml.Name `xml:"http://www.sitemaps.org/schemas/sitemap/0.9 urlset"`
+	ImageXMLNS string   `xml:"xmlns:image,attr"`
+	URLs       []URL    `xml:"url"`
+}
+
+// URL is a single <url> entry.
+type URL struct {
+	Loc        string  `xml:"loc"`
+	LastMod    string  `xml:"lastmod,omitempty"`
+	ChangeFreq string  `xml:"changefreq,omitempty"`
+	Priority   string  `xml:"priority,omitempty"`
+	Images     []Image `xml:"image:image,omitempty"`
+}
+
+// Image is a Google image sitemap extension <image:image> entry.
+type Image struct {
+	Loc string `xml:"image:loc"`
+}
+
+// Index is the root <sitemapindex> element referencing the per-part
+// sitemaps of a site too large for a single document.
+type Index struct {
+	XMLName  xml.Name     `xml:"http://www.sitemaps.org/schemas/sitemap/0.9 sitemapindex"`
+	Sitemaps []IndexEntry `xml:"sitemap"`
+}
+
+// IndexEntry is a single <sitemap> entry in a sitemap index.
+type IndexEntry struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+const imageXMLNS = "http://www.google.com/schemas/sitemap-image/1.1"
+
+// NewURLSet returns an empty URLSet with the image extension namespace
+// declared, ready to have urls appended to its URLs field.
+func NewURLSet() URLSet {
+	return URLSet{ImageXMLNS: imageXMLNS}
+}
+
+// Marshal renders doc (a URLSet or Index) as a complete XML document,
+// including the <?xml?> declaration.
+func Marshal(doc any) ([]byte, error) {
+	b, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), b...), nil
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"myBolg/internal/feed"
+)
+
+func (s *Site) handleJSONFeed(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/feed.json" {
+		http.NotFound(w, r)
+		return
+	}
+
+	posts, err := s.Posts()
+	if err != nil {
+		http.Error(w, "读取文章失败", http.StatusInternalServerError)
+		return
+	}
+
+	doc, err := s.buildJSONFeed(posts)
+	if err != nil {
+		http.Error(w, "渲染失败", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/feed+json; charset=utf-8")
+	_ = json.NewEncoder(w).Encode(doc)
+}
+
+// buildJSONFeed assembles the JSON Feed 1.1 document for posts.
+func (s *Site) buildJSONFeed(posts []post) (feed.JSONFeed, error) {
+	baseURL := s.Config.Site.BaseURL
+	doc := feed.JSONFeed{
+		Version:     "https://jsonfeed.org/version/1.1",
+		Title:       s.Config.Site.Title,
+		HomePageURL: baseURL + "/",
+		FeedURL:     baseURL + "/feed.json",
+		Authors:     []feed.JSONFeedAuthor{{Name: feedAuthor(s.Config)}},
+	}
+
+	for _, p := range posts {
+		_, body, err := s.renderPostBySlug(p.Slug)
+		if err != nil {
+			return feed.JSONFeed{}, err
+		}
+
+		published := coalesceTime(p.Date, p.ModTime)
+		modified := coalesceTime(p.ModTime, published)
+		doc.Items = append(doc.Items, feed.JSONFeedItem{
+			ID:            baseURL + "/post/" + p.Slug,
+			URL:           baseURL + "/post/" + p.Slug,
+			Title:         p.Title,
+			ContentHTML:   string(body),
+			DatePublished: published,
+			DateModified:  modified,
+			Tags:          p.Tags,
+		})
+	}
+
+	return doc, nil
+}
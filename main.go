@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+)
+
+func main() {
+	if len(os.Args) > 1 && os.Args[1] == "build" {
+		runBuild(os.Args[2:])
+		return
+	}
+	runServe(os.Args[1:])
+}
+
+func runServe(args []string) {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := fs.String("addr", "", "listen address (overrides config.toml's [server].listen)")
+	configPath := fs.String("config", "config.toml", "path to config.toml")
+	postsDir := fs.String("posts", "posts", "posts directory")
+	staticDir := fs.String("static", "static", "static assets directory")
+	drafts := fs.Bool("drafts", false, "include draft posts")
+	dev := fs.Bool("dev", false, "watch posts/static for changes and live-reload the browser")
+	_ = fs.Parse(args)
+
+	devMode = *dev
+
+	cfg, err := loadConfig(*configPath)
+	if err != nil {
+		log.Fatalf("config: %v", err)
+	}
+	if *addr != "" {
+		cfg.Server.Listen = *addr
+	}
+
+	site := NewSite(*postsDir, *drafts, cfg)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", site.handleIndex)
+	mux.HandleFunc("/post/", site.handlePost)
+	mux.HandleFunc("/tags/", site.handleTags)
+	mux.HandleFunc("/rss.xml", site.handleRSS)
+	mux.HandleFunc("/atom.xml", site.handleAtom)
+	mux.HandleFunc("/feed.json", site.handleJSONFeed)
+	mux.HandleFunc("/sitemap.xml", site.handleSitemap)
+	if err := site.registerSitemapParts(mux); err != nil {
+		log.Fatalf("sitemap: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if devMode {
+		reloader := newDevReloader()
+		mux.HandleFunc("/_reload", reloader.handleSSE)
+		if err := watchForChanges(ctx, site, reloader, *postsDir, *staticDir); err != nil {
+			log.Fatalf("dev: %v", err)
+		}
+	}
+
+	srv := &http.Server{
+		Addr:        cfg.Server.Listen,
+		Handler:     securityHeaders(cfg, mux),
+		ReadTimeout: cfg.Server.ReadTimeout,
+	}
+
+	go func() {
+		log.Printf("Blog running at %s", listenURL(cfg.Server.Listen))
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatal(err)
+		}
+	}()
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+	<-stop
+	cancel()
+
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer shutdownCancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		log.Printf("shutdown: %v", err)
+	}
+}
+
+// listenURL turns a cfg.Server.Listen value into a browsable URL: a bare
+// port like ":8080" becomes "http://localhost:8080", while an address that
+// already names a host (e.g. "127.0.0.1:8899") is used as-is.
+func listenURL(addr string) string {
+	if strings.HasPrefix(addr, ":") {
+		return "http://localhost" + addr
+	}
+	return "http://" + addr
+}
+
+func runBuild(args []string) {
+	fs := flag.NewFlagSet("build", flag.ExitOnError)
+	out := fs.String("o", "public", "output directory")
+	configPath := fs.String("config", "config.toml", "path to config.toml")
+	postsDir := fs.String("posts", "posts", "posts directory")
+	staticDir := fs.String("static", "static", "static assets directory")
+	drafts := fs.Bool("drafts", false, "include draft posts")
+	_ = fs.Parse(args)
+
+	cfg, err := loadConfig(*configPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "config:", err)
+		os.Exit(1)
+	}
+
+	site := NewSite(*postsDir, *drafts, cfg)
+	if err := Build(site, *out, *staticDir); err != nil {
+		fmt.Fprintln(os.Stderr, "build failed:", err)
+		os.Exit(1)
+	}
+	fmt.Printf("built site into %s\n", *out)
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"html/template"
+	"net/url"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Transform mutates a rendered post's HTML document in place. Site.Transforms
+// is a chain of these, run in order on the goldmark output before the
+// result is sent to handlePost or written by Build.
+type Transform func(doc *goquery.Document, p post) error
+
+// defaultTransforms is the built-in post-processing chain. baseURL is used
+// to tell the site's own links apart from external ones.
+func defaultTransforms(baseURL string) []Transform {
+	siteHost := feedHost(baseURL)
+	return []Transform{
+		lazyLoadImages,
+		externalLinksNewTab(siteHost),
+		rewriteRelativeImages,
+		injectTableOfContents,
+		wrapCodeBlocks,
+	}
+}
+
+// applyTransforms runs transforms over html in order and returns the
+// serialized result.
+func applyTransforms(html string, p post, transforms []Transform) (string, error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(`<div id="__root">` + html + `</div>`))
+	if err != nil {
+		return "", err
+	}
+
+	for _, t := range transforms {
+		if err := t(doc, p); err != nil {
+			return "", err
+		}
+	}
+
+	return doc.Find("#__root").Html()
+}
+
+// lazyLoadImages adds loading="lazy" and decoding="async" to every <img>.
+func lazyLoadImages(doc *goquery.Document, p post) error {
+	doc.Find("img").Each(func(_ int, img *goquery.Selection) {
+		if _, ok := img.Attr("loading"); !ok {
+			img.SetAttr("loading", "lazy")
+		}
+		if _, ok := img.Attr("decoding"); !ok {
+			img.SetAttr("decoding", "async")
+		}
+	})
+	return nil
+}
+
+// externalLinksNewTab returns a Transform that opens links to hosts other
+// than siteHost in a new tab without leaking a referrer or opener.
+func externalLinksNewTab(siteHost string) Transform {
+	return func(doc *goquery.Document, p post) error {
+		doc.Find("a[href]").Each(func(_ int, a *goquery.Selection) {
+			href, _ := a.Attr("href")
+			u, err := url.Parse(href)
+			if err != nil || u.Host == "" || u.Host == siteHost {
+				return
+			}
+			a.SetAttr("rel", "noopener noreferrer")
+			a.SetAttr("target", "_blank")
+		})
+		return nil
+	}
+}
+
+// rewriteRelativeImages rewrites image paths that are relative to the
+// markdown file so they resolve under the post's own URL. This lets
+// images live next to the markdown source, in postsDir/<slug>/, which
+// handlePostAsset serves and Build copies alongside the post's
+// index.html.
+func rewriteRelativeImages(doc *goquery.Document, p post) error {
+	doc.Find("img[src]").Each(func(_ int, img *goquery.Selection) {
+		src, _ := img.Attr("src")
+		u, err := url.Parse(src)
+		if err != nil || u.IsAbs() || strings.HasPrefix(src, "/") {
+			return
+		}
+		img.SetAttr("src", "/post/"+p.Slug+"/"+src)
+	})
+	return nil
+}
+
+// postImageURLs returns the src of every <img> in html, used to populate
+// a post's sitemap image entries after transforms (such as
+// rewriteRelativeImages) have run.
+func postImageURLs(html string) []string {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return nil
+	}
+
+	var urls []string
+	doc.Find("img[src]").Each(func(_ int, img *goquery.Selection) {
+		if src, ok := img.Attr("src"); ok {
+			urls = append(urls, src)
+		}
+	})
+	return urls
+}
+
+// injectTableOfContents collects h2/h3 headings into a <nav> prepended to
+// the article.
+func injectTableOfContents(doc *goquery.Document, p post) error {
+	headings := doc.Find("h2, h3")
+	if headings.Length() == 0 {
+		return nil
+	}
+
+	var toc strings.Builder
+	toc.WriteString(`<nav class="toc"><strong>目录</strong><ul>`)
+	headings.Each(func(_ int, h *goquery.Selection) {
+		id, _ := h.Attr("id")
+		toc.WriteString(`<li class="toc-` + goquery.NodeName(h) + `">`)
+		if id != "" {
+			toc.WriteString(`<a href="#` + id + `">`)
+		}
+		toc.WriteString(template.HTMLEscapeString(h.Text()))
+		if id != "" {
+			toc.WriteString("</a>")
+		}
+		toc.WriteString("</li>")
+	})
+	toc.WriteString("</ul></nav>")
+
+	doc.Find("#__root").PrependHtml(toc.String())
+	return nil
+}
+
+// wrapCodeBlocks wraps raw <pre><code> blocks in a <figure> with a copy
+// button so the block can be styled and copied as a unit.
+func wrapCodeBlocks(doc *goquery.Document, p post) error {
+	doc.Find("pre").Each(func(_ int, pre *goquery.Selection) {
+		if pre.Closest("figure").Length() > 0 {
+			return
+		}
+		pre.WrapHtml(`<figure class="code-block"></figure>`)
+		pre.BeforeHtml(`<button type="button" class="copy-button">复制</button>`)
+	})
+	return nil
+}
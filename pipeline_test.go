@@ -0,0 +1,99 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func render(t *testing.T, html string, p post, transforms ...Transform) string {
+	t.Helper()
+	out, err := applyTransforms(html, p, transforms)
+	if err != nil {
+		t.Fatalf("applyTransforms: %v", err)
+	}
+	return out
+}
+
+func TestLazyLoadImages(t *testing.T) {
+	out := render(t, `<img src="a.png">`, post{}, lazyLoadImages)
+	if !strings.Contains(out, `loading="lazy"`) || !strings.Contains(out, `decoding="async"`) {
+		t.Errorf("missing lazy-load attrs: %s", out)
+	}
+}
+
+func TestLazyLoadImagesRespectsExistingAttr(t *testing.T) {
+	out := render(t, `<img src="a.png" loading="eager">`, post{}, lazyLoadImages)
+	if !strings.Contains(out, `loading="eager"`) {
+		t.Errorf("existing loading attr was overwritten: %s", out)
+	}
+}
+
+func TestExternalLinksNewTab(t *testing.T) {
+	transform := externalLinksNewTab("example.com")
+	out := render(t, `<a href="https://other.com/x">x</a><a href="/local">local</a>`, post{}, transform)
+
+	if !strings.Contains(out, `target="_blank"`) || !strings.Contains(out, `rel="noopener noreferrer"`) {
+		t.Errorf("external link missing target/rel: %s", out)
+	}
+	if strings.Contains(out, `href="/local" rel`) || strings.Contains(out, `href="/local" target`) {
+		t.Errorf("local link should be untouched: %s", out)
+	}
+}
+
+func TestRewriteRelativeImages(t *testing.T) {
+	p := post{Slug: "hello"}
+	out := render(t, `<img src="pic.png">`, p, rewriteRelativeImages)
+	if !strings.Contains(out, `src="/post/hello/pic.png"`) {
+		t.Errorf("relative image was not rewritten: %s", out)
+	}
+}
+
+func TestRewriteRelativeImagesLeavesAbsoluteAlone(t *testing.T) {
+	p := post{Slug: "hello"}
+	out := render(t, `<img src="https://cdn.example.com/pic.png">`, p, rewriteRelativeImages)
+	if !strings.Contains(out, `src="https://cdn.example.com/pic.png"`) {
+		t.Errorf("absolute image src was rewritten: %s", out)
+	}
+
+	out = render(t, `<img src="/already/rooted.png">`, p, rewriteRelativeImages)
+	if !strings.Contains(out, `src="/already/rooted.png"`) {
+		t.Errorf("root-relative image src was rewritten: %s", out)
+	}
+}
+
+func TestPostImageURLs(t *testing.T) {
+	urls := postImageURLs(`<p><img src="/post/hello/pic.png"><img src="/post/hello/other.png"></p>`)
+	want := []string{"/post/hello/pic.png", "/post/hello/other.png"}
+	if len(urls) != len(want) {
+		t.Fatalf("got %v, want %v", urls, want)
+	}
+	for i, u := range urls {
+		if u != want[i] {
+			t.Errorf("urls[%d] = %q, want %q", i, u, want[i])
+		}
+	}
+}
+
+func TestInjectTableOfContents(t *testing.T) {
+	out := render(t, `<h2 id="a">A</h2><h3 id="b">B</h3>`, post{}, injectTableOfContents)
+	if !strings.Contains(out, `class="toc"`) {
+		t.Errorf("no toc injected: %s", out)
+	}
+	if !strings.Contains(out, `href="#a"`) || !strings.Contains(out, `href="#b"`) {
+		t.Errorf("toc missing heading links: %s", out)
+	}
+}
+
+func TestInjectTableOfContentsNoHeadings(t *testing.T) {
+	out := render(t, `<p>no headings here</p>`, post{}, injectTableOfContents)
+	if strings.Contains(out, `class="toc"`) {
+		t.Errorf("toc injected with no headings: %s", out)
+	}
+}
+
+func TestWrapCodeBlocks(t *testing.T) {
+	out := render(t, `<pre><code>x := 1</code></pre>`, post{}, wrapCodeBlocks)
+	if !strings.Contains(out, `class="code-block"`) || !strings.Contains(out, `class="copy-button"`) {
+		t.Errorf("code block not wrapped: %s", out)
+	}
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"bytes"
+	"html/template"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/yuin/goldmark"
+	highlighting "github.com/yuin/goldmark-highlighting/v2"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer/html"
+)
+
+type post struct {
+	Title   string
+	Slug    string
+	Summary string
+	Date    time.Time
+	ModTime time.Time
+	Tags    []string
+	Draft   bool
+
+	// Images is the rendered post's <img src> URLs after the same
+	// transforms renderPostBySlug applies: root-relative (e.g.
+	// "/post/<slug>/<file>") for local images, and unchanged for absolute
+	// external ones. Used to populate the sitemap's image
+	// extension without re-rendering every post on each sitemap request.
+	Images []string
+}
+
+var md = goldmark.New(
+	goldmark.WithExtensions(
+		extension.GFM,
+		highlighting.NewHighlighting(
+			highlighting.WithStyle("github"),
+		),
+	),
+	goldmark.WithParserOptions(
+		parser.WithAutoHeadingID(),
+	),
+	goldmark.WithRendererOptions(
+		html.WithUnsafe(),
+	),
+)
+
+// listPosts reads every markdown file in s.PostsDir and returns the
+// resulting posts sorted newest first. Draft posts are omitted unless
+// includeDrafts is set. Each post's rendered image URLs are collected for
+// the sitemap.
+func (s *Site) listPosts(includeDrafts bool) ([]post, error) {
+	matches, err := filepath.Glob(filepath.Join(s.PostsDir, "*.md"))
+	if err != nil {
+		return nil, err
+	}
+
+	posts := make([]post, 0, len(matches))
+	for _, path := range matches {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		info, _ := os.Stat(path)
+		slug := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		fm, body := parseFrontMatter(string(b))
+
+		if fm.Draft && !includeDrafts {
+			continue
+		}
+
+		title := extractTitle(fm, body)
+		if title == "" {
+			title = slug
+		}
+		p := post{
+			Title:   title,
+			Slug:    slug,
+			Summary: extractSummary(fm, body),
+			Date:    fm.date(),
+			Tags:    fm.Taxonomies.Tags,
+			Draft:   fm.Draft,
+		}
+		if info != nil {
+			p.ModTime = info.ModTime()
+		}
+
+		var buf bytes.Buffer
+		if err := md.Convert([]byte(body), &buf); err == nil {
+			if rendered, err := applyTransforms(buf.String(), p, s.Transforms); err == nil {
+				p.Images = postImageURLs(rendered)
+			}
+		}
+
+		posts = append(posts, p)
+	}
+
+	sort.Slice(posts, func(i, j int) bool {
+		a := posts[i]
+		b := posts[j]
+		if !a.Date.IsZero() && !b.Date.IsZero() {
+			return a.Date.After(b.Date)
+		}
+		if !a.Date.IsZero() {
+			return true
+		}
+		if !b.Date.IsZero() {
+			return false
+		}
+		return a.ModTime.After(b.ModTime)
+	})
+
+	return posts, nil
+}
+
+// renderPostBySlug reads and renders the markdown file for slug, returning
+// the parsed post metadata alongside its rendered HTML body.
+func (s *Site) renderPostBySlug(slug string) (post, template.HTML, error) {
+	mdPath := filepath.Join(s.PostsDir, slug+".md")
+	b, err := os.ReadFile(mdPath)
+	if err != nil {
+		return post{}, "", err
+	}
+
+	fm, body := parseFrontMatter(string(b))
+	if fm.Draft && !s.Drafts {
+		return post{}, "", os.ErrNotExist
+	}
+
+	var buf bytes.Buffer
+	if err := md.Convert([]byte(body), &buf); err != nil {
+		return post{}, "", err
+	}
+
+	title := extractTitle(fm, body)
+	if title == "" {
+		title = slug
+	}
+
+	p := post{
+		Title:   title,
+		Slug:    slug,
+		Summary: extractSummary(fm, body),
+		Date:    fm.date(),
+		Tags:    fm.Taxonomies.Tags,
+		Draft:   fm.Draft,
+	}
+
+	out, err := applyTransforms(buf.String(), p, s.Transforms)
+	if err != nil {
+		return post{}, "", err
+	}
+
+	return p, template.HTML(out), nil
+}
+
+func extractTitle(fm frontMatter, mdText string) string {
+	if v := strings.TrimSpace(fm.Title); v != "" {
+		return v
+	}
+	for _, line := range strings.Split(mdText, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "# ") {
+			return strings.TrimSpace(strings.TrimPrefix(line, "# "))
+		}
+	}
+	return ""
+}
+
+func extractSummary(fm frontMatter, mdText string) string {
+	if v := strings.TrimSpace(fm.Description); v != "" {
+		return v
+	}
+	for _, line := range strings.Split(mdText, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "```") {
+			continue
+		}
+		return line
+	}
+	return ""
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"bytes"
+	"html/template"
+	"strings"
+	"time"
+)
+
+// The layout's <style> stays inline rather than a separate static asset:
+// the site has no build step or cache-busting for static files, so an
+// external stylesheet would need its own versioning to avoid going stale
+// behind a cached HTML page. This is why defaultConfig's style-src keeps
+// 'unsafe-inline' — unlike script-src, which only needs it in dev mode.
+var layout = template.Must(template.New("layout").Parse(`<!doctype html>
+<html lang="zh-CN">
+  <head>
+	<meta charset="utf-8" />
+	<meta name="viewport" content="width=device-width, initial-scale=1" />
+	<title>{{ .Title }}</title>
+	<link rel="alternate" type="application/rss+xml" title="RSS" href="/rss.xml" />
+	<link rel="alternate" type="application/atom+xml" title="Atom" href="/atom.xml" />
+	<link rel="alternate" type="application/feed+json" title="JSON Feed" href="/feed.json" />
+	<style>
+	  :root { color-scheme: light dark; }
+	  body { max-width: 880px; margin: 48px auto; padding: 0 20px; font: 16px/1.7 system-ui, -apple-system, "Segoe UI", sans-serif; }
+	  a { text-decoration: none; }
+	  header { margin-bottom: 28px; }
+	  nav a { margin-right: 12px; }
+	  pre { padding: 12px; overflow: auto; border-radius: 8px; background: #f6f8fa; border: 1px solid #e5e7eb; }
+	  pre code { display: block; }
+	  code { font-family: ui-monospace, SFMono-Regular, Menlo, Consolas, "Liberation Mono", monospace; background: #f6f8fa; padding: 2px 4px; border-radius: 4px; }
+	  .post-list { list-style: none; padding: 0; }
+	  .post-list li { margin: 16px 0; padding-bottom: 12px; border-bottom: 1px solid #ddd; }
+	  .post-meta { color: #777; font-size: 14px; }
+	  .post-tags { margin-top: 6px; }
+	  .tag-chip { display: inline-block; margin: 0 6px 0 0; padding: 2px 8px; border-radius: 999px; background: #f0f1f3; font-size: 13px; color: #555; }
+	  .toc { padding: 12px 16px; margin-bottom: 24px; border: 1px solid #e5e7eb; border-radius: 8px; }
+	  .toc ul { margin: 8px 0 0; padding-left: 20px; }
+	  .code-block { position: relative; margin: 16px 0; }
+	  .code-block .copy-button { position: absolute; top: 8px; right: 8px; font-size: 12px; padding: 2px 8px; border-radius: 4px; border: 1px solid #e5e7eb; background: #fff; cursor: pointer; }
+	  footer { margin-top: 48px; color: #888; font-size: 14px; }
+	</style>
+  </head>
+  <body>
+	<header>
+	  <nav>
+		<a href="/">首页</a>
+		<a href="/tags/">标签</a>
+		<a href="/rss.xml">RSS</a>
+	  </nav>
+	</header>
+	{{ .Content }}
+	<footer>© {{ .Year }} 博客</footer>
+	{{ if .DevReload }}
+	<script>
+	  new EventSource("/_reload").onmessage = () => location.reload();
+	</script>
+	{{ end }}
+  </body>
+</html>`))
+
+// renderPage wraps content in the site layout and returns the rendered
+// HTML page, used by both the HTTP handlers and the static builder. In
+// dev mode it injects the live-reload script.
+func renderPage(title string, content template.HTML) ([]byte, error) {
+	var buf bytes.Buffer
+	err := layout.Execute(&buf, map[string]any{
+		"Title":     title,
+		"Content":   content,
+		"Year":      time.Now().Year(),
+		"DevReload": devMode,
+	})
+	return buf.Bytes(), err
+}
+
+func renderIndexContent(posts []post) template.HTML {
+	var sb strings.Builder
+	sb.WriteString("<h1>博客</h1><ul class=\"post-list\">")
+	for _, p := range posts {
+		sb.WriteString("<li>")
+		sb.WriteString("<a href=\"/post/")
+		sb.WriteString(p.Slug)
+		sb.WriteString("\">")
+		sb.WriteString(template.HTMLEscapeString(p.Title))
+		sb.WriteString("</a>")
+		sb.WriteString("<div class=\"post-meta\">")
+		if !p.Date.IsZero() {
+			sb.WriteString(p.Date.Format("2006-01-02"))
+		} else if !p.ModTime.IsZero() {
+			sb.WriteString(p.ModTime.Format("2006-01-02"))
+		}
+		sb.WriteString("</div>")
+		if p.Summary != "" {
+			sb.WriteString("<div>")
+			sb.WriteString(template.HTMLEscapeString(p.Summary))
+			sb.WriteString("</div>")
+		}
+		sb.WriteString(string(tagChips(p.Tags)))
+		sb.WriteString("</li>")
+	}
+	sb.WriteString("</ul>")
+	return template.HTML(sb.String())
+}
+
+// renderPostContent wraps a rendered post body with its title and tag
+// chips, used for the single-post page.
+func renderPostContent(p post, body template.HTML) template.HTML {
+	var sb strings.Builder
+	sb.WriteString("<article>")
+	sb.WriteString("<header>")
+	sb.WriteString("<h1>")
+	sb.WriteString(template.HTMLEscapeString(p.Title))
+	sb.WriteString("</h1>")
+	sb.WriteString(string(tagChips(p.Tags)))
+	sb.WriteString("</header>")
+	sb.WriteString(string(body))
+	sb.WriteString("</article>")
+	return template.HTML(sb.String())
+}
+
+func renderRSS(posts []post, cfg Config) []byte {
+	baseURL := cfg.Site.BaseURL
+	var sb strings.Builder
+	sb.WriteString("<?xml version=\"1.0\" encoding=\"UTF-8\"?>")
+	sb.WriteString("<rss version=\"2.0\"><channel>")
+	sb.WriteString("<title>")
+	sb.WriteString(template.HTMLEscapeString(cfg.Site.Title))
+	sb.WriteString("</title>")
+	sb.WriteString("<link>")
+	sb.WriteString(baseURL)
+	sb.WriteString("</link>")
+	sb.WriteString("<description>")
+	sb.WriteString(template.HTMLEscapeString(cfg.Site.Description))
+	sb.WriteString("</description>")
+	for _, p := range posts {
+		url := baseURL + "/post/" + p.Slug
+		pub := p.Date
+		if pub.IsZero() {
+			pub = p.ModTime
+		}
+		if pub.IsZero() {
+			pub = time.Now()
+		}
+		sb.WriteString("<item>")
+		sb.WriteString("<title>")
+		sb.WriteString(template.HTMLEscapeString(p.Title))
+		sb.WriteString("</title>")
+		sb.WriteString("<link>")
+		sb.WriteString(url)
+		sb.WriteString("</link>")
+		sb.WriteString("<guid>")
+		sb.WriteString(url)
+		sb.WriteString("</guid>")
+		sb.WriteString("<pubDate>")
+		sb.WriteString(pub.Format(time.RFC1123Z))
+		sb.WriteString("</pubDate>")
+		if p.Summary != "" {
+			sb.WriteString("<description>")
+			sb.WriteString(template.HTMLEscapeString(p.Summary))
+			sb.WriteString("</description>")
+		}
+		for _, tag := range p.Tags {
+			sb.WriteString("<category>")
+			sb.WriteString(template.HTMLEscapeString(tag))
+			sb.WriteString("</category>")
+		}
+		sb.WriteString("</item>")
+	}
+	sb.WriteString("</channel></rss>")
+	return []byte(sb.String())
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"net/http"
+	"path/filepath"
+	"strings"
+)
+
+func (s *Site) handleIndex(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+
+	posts, err := s.Posts()
+	if err != nil {
+		http.Error(w, "读取文章失败", http.StatusInternalServerError)
+		return
+	}
+
+	page, err := renderPage("首页", renderIndexContent(posts))
+	if err != nil {
+		http.Error(w, "渲染失败", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = w.Write(page)
+}
+
+func (s *Site) handlePost(w http.ResponseWriter, r *http.Request) {
+	rest := strings.TrimPrefix(r.URL.Path, "/post/")
+	slug, asset, hasAsset := strings.Cut(rest, "/")
+	if slug == "" {
+		http.NotFound(w, r)
+		return
+	}
+	if hasAsset {
+		s.handlePostAsset(w, r, slug, asset)
+		return
+	}
+
+	p, body, err := s.renderPostBySlug(slug)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	page, err := renderPage(p.Title, renderPostContent(p, body))
+	if err != nil {
+		http.Error(w, "渲染失败", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = w.Write(page)
+}
+
+// handlePostAsset serves a file kept alongside a post's markdown, in
+// postsDir/<slug>/, so images referenced with a path relative to the
+// markdown (rewritten by rewriteRelativeImages to "/post/<slug>/<file>")
+// actually resolve.
+func (s *Site) handlePostAsset(w http.ResponseWriter, r *http.Request, slug, asset string) {
+	if asset == "" {
+		http.NotFound(w, r)
+		return
+	}
+	http.ServeFile(w, r, filepath.Join(s.PostsDir, slug, asset))
+}
+
+func (s *Site) handleRSS(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/rss.xml" {
+		http.NotFound(w, r)
+		return
+	}
+
+	posts, err := s.Posts()
+	if err != nil {
+		http.Error(w, "读取文章失败", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/rss+xml; charset=utf-8")
+	_, _ = w.Write(renderRSS(posts, s.Config))
+}
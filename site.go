@@ -0,0 +1,59 @@
+package main
+
+import "sync"
+
+// Site holds the configuration and state shared by the HTTP handlers and
+// the static builder, so both render posts through the same functions
+// instead of duplicating markup in each caller.
+type Site struct {
+	PostsDir string
+	Drafts   bool
+	Config   Config
+
+	// Transforms is the ordered chain of HTML post-processors run on a
+	// post's rendered body before it is served or built. Defaults to
+	// defaultTransforms(); callers may append their own.
+	Transforms []Transform
+
+	mu    sync.RWMutex
+	cache []post
+	valid bool
+}
+
+// NewSite returns a Site that reads posts from postsDir. When drafts is
+// true, posts marked draft in their front matter are included.
+func NewSite(postsDir string, drafts bool, cfg Config) *Site {
+	return &Site{PostsDir: postsDir, Drafts: drafts, Config: cfg, Transforms: defaultTransforms(cfg.Site.BaseURL)}
+}
+
+// Posts returns the site's posts, sorted newest first, serving from an
+// in-memory cache until InvalidatePosts is called.
+func (s *Site) Posts() ([]post, error) {
+	s.mu.RLock()
+	if s.valid {
+		cached := s.cache
+		s.mu.RUnlock()
+		return cached, nil
+	}
+	s.mu.RUnlock()
+
+	posts, err := s.listPosts(s.Drafts)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.cache = posts
+	s.valid = true
+	s.mu.Unlock()
+
+	return posts, nil
+}
+
+// InvalidatePosts clears the cached post list so the next call to Posts
+// re-reads from disk. Used by the dev-mode filesystem watcher.
+func (s *Site) InvalidatePosts() {
+	s.mu.Lock()
+	s.valid = false
+	s.mu.Unlock()
+}
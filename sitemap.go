@@ -0,0 +1,263 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"myBolg/internal/sitemap"
+)
+
+// sitemaps.org caps a single sitemap file at 50,000 URLs or 50 MB
+// uncompressed; past either limit a site must split into numbered
+// sitemap-N.xml files referenced from a sitemapindex.
+const (
+	maxSitemapURLs  = 50000
+	maxSitemapBytes = 50 * 1024 * 1024
+)
+
+// registerSitemapParts registers a "/sitemap-N.xml" route for each part
+// the site currently needs, so the literal file names referenced by the
+// sitemap-index resolve. Run once at startup: a site crossing the
+// 50,000-URL or 50 MB split threshold mid-process needs a restart to pick
+// up the new route count.
+func (s *Site) registerSitemapParts(mux *http.ServeMux) error {
+	posts, err := s.Posts()
+	if err != nil {
+		return err
+	}
+	parts, err := s.sitemapParts(posts)
+	if err != nil {
+		return err
+	}
+	for i := range parts {
+		n := i + 1
+		mux.HandleFunc(fmt.Sprintf("/sitemap-%d.xml", n), func(w http.ResponseWriter, r *http.Request) {
+			s.handleSitemapPart(w, r, n)
+		})
+	}
+	return nil
+}
+
+func (s *Site) handleSitemap(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/sitemap.xml" {
+		http.NotFound(w, r)
+		return
+	}
+
+	posts, err := s.Posts()
+	if err != nil {
+		http.Error(w, "读取文章失败", http.StatusInternalServerError)
+		return
+	}
+
+	parts, err := s.sitemapParts(posts)
+	if err != nil {
+		http.Error(w, "渲染失败", http.StatusInternalServerError)
+		return
+	}
+
+	var body []byte
+	if len(parts) == 1 {
+		body, err = sitemap.Marshal(parts[0])
+	} else {
+		body, err = sitemap.Marshal(sitemapIndex(parts, s.Config.Site.BaseURL, latestUpdate(posts)))
+	}
+	if err != nil {
+		http.Error(w, "渲染失败", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	_, _ = w.Write(body)
+}
+
+// handleSitemapPart serves the nth (1-indexed) sitemap-N.xml, one of the
+// files a sitemap.xml index points to once the site outgrows a single
+// sitemap document.
+func (s *Site) handleSitemapPart(w http.ResponseWriter, r *http.Request, n int) {
+	posts, err := s.Posts()
+	if err != nil {
+		http.Error(w, "读取文章失败", http.StatusInternalServerError)
+		return
+	}
+
+	parts, err := s.sitemapParts(posts)
+	if err != nil || n < 1 || n > len(parts) {
+		http.NotFound(w, r)
+		return
+	}
+
+	body, err := sitemap.Marshal(parts[n-1])
+	if err != nil {
+		http.Error(w, "渲染失败", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	_, _ = w.Write(body)
+}
+
+// sitemapParts builds posts's sitemap URL entries and splits them into
+// one or more URLSets, each within the sitemaps.org URL-count and
+// byte-size limits.
+func (s *Site) sitemapParts(posts []post) ([]sitemap.URLSet, error) {
+	idx := tagIndex(posts)
+	urls := sitemapURLs(posts, sortedTags(idx), s.Config.Site.BaseURL)
+	return splitSitemapURLs(urls)
+}
+
+// sitemapURLs builds one sitemap entry for the home page, each post (with
+// lastmod, changefreq, priority, and its images), the tags index, and
+// each tag page.
+func sitemapURLs(posts []post, tags []string, baseURL string) []sitemap.URL {
+	urls := []sitemap.URL{
+		{Loc: baseURL, ChangeFreq: "daily", Priority: "0.5"},
+	}
+
+	for _, p := range posts {
+		u := sitemap.URL{
+			Loc:        baseURL + "/post/" + p.Slug,
+			LastMod:    sitemapLastMod(p),
+			ChangeFreq: "monthly",
+			Priority:   "0.8",
+		}
+		for _, img := range p.Images {
+			u.Images = append(u.Images, sitemap.Image{Loc: absoluteURL(baseURL, img)})
+		}
+		urls = append(urls, u)
+	}
+
+	urls = append(urls, sitemap.URL{Loc: baseURL + "/tags/", ChangeFreq: "weekly", Priority: "0.3"})
+	for _, tag := range tags {
+		slug := tagSlug(tag)
+		if slug == "" {
+			continue
+		}
+		// PathEscape so a non-ASCII slug (e.g. a CJK tag) doesn't reach
+		// <loc> as raw UTF-8, which some sitemap consumers reject.
+		urls = append(urls, sitemap.URL{Loc: baseURL + "/tags/" + url.PathEscape(slug) + "/", ChangeFreq: "weekly", Priority: "0.3"})
+	}
+
+	return urls
+}
+
+// sitemapLastMod formats a post's front-matter date, falling back to its
+// mod time, as a W3C datetime, or "" if the post has neither.
+func sitemapLastMod(p post) string {
+	t := coalesceTime(p.Date, p.ModTime)
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// absoluteURL qualifies a post image src with baseURL unless it is
+// already an absolute URL (an external image left untouched by
+// rewriteRelativeImages).
+func absoluteURL(baseURL, src string) string {
+	if strings.Contains(src, "://") {
+		return src
+	}
+	return baseURL + src
+}
+
+// splitSitemapURLs packs urls into one or more URLSets, each holding at
+// most maxSitemapURLs entries and, if still too large once images are
+// counted, repeatedly halved until its serialized size is under
+// maxSitemapBytes.
+func splitSitemapURLs(urls []sitemap.URL) ([]sitemap.URLSet, error) {
+	if len(urls) == 0 {
+		return fitSitemapChunk(nil)
+	}
+
+	var sets []sitemap.URLSet
+	for start := 0; start < len(urls); start += maxSitemapURLs {
+		end := start + maxSitemapURLs
+		if end > len(urls) {
+			end = len(urls)
+		}
+		chunks, err := fitSitemapChunk(urls[start:end])
+		if err != nil {
+			return nil, err
+		}
+		sets = append(sets, chunks...)
+	}
+	return sets, nil
+}
+
+// fitSitemapChunk wraps chunk in a URLSet, halving it until the
+// serialized document fits under maxSitemapBytes.
+func fitSitemapChunk(chunk []sitemap.URL) ([]sitemap.URLSet, error) {
+	set := sitemap.NewURLSet()
+	set.URLs = chunk
+	b, err := sitemap.Marshal(set)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) <= maxSitemapBytes || len(chunk) <= 1 {
+		return []sitemap.URLSet{set}, nil
+	}
+
+	mid := len(chunk) / 2
+	left, err := fitSitemapChunk(chunk[:mid])
+	if err != nil {
+		return nil, err
+	}
+	right, err := fitSitemapChunk(chunk[mid:])
+	if err != nil {
+		return nil, err
+	}
+	return append(left, right...), nil
+}
+
+// buildSitemap writes posts's sitemap into outDir: a single sitemap.xml
+// if it fits the sitemaps.org limits, or sitemap-1.xml, sitemap-2.xml,
+// ... plus a sitemap.xml index once it doesn't.
+func (s *Site) buildSitemap(posts []post, outDir string) error {
+	parts, err := s.sitemapParts(posts)
+	if err != nil {
+		return err
+	}
+
+	if len(parts) == 1 {
+		b, err := sitemap.Marshal(parts[0])
+		if err != nil {
+			return err
+		}
+		return writeFile(filepath.Join(outDir, "sitemap.xml"), b)
+	}
+
+	for i, part := range parts {
+		b, err := sitemap.Marshal(part)
+		if err != nil {
+			return err
+		}
+		name := fmt.Sprintf("sitemap-%d.xml", i+1)
+		if err := writeFile(filepath.Join(outDir, name), b); err != nil {
+			return err
+		}
+	}
+
+	indexBytes, err := sitemap.Marshal(sitemapIndex(parts, s.Config.Site.BaseURL, latestUpdate(posts)))
+	if err != nil {
+		return err
+	}
+	return writeFile(filepath.Join(outDir, "sitemap.xml"), indexBytes)
+}
+
+// sitemapIndex builds the sitemap-index document pointing at each of
+// parts' sitemap-N.xml files.
+func sitemapIndex(parts []sitemap.URLSet, baseURL string, lastMod time.Time) sitemap.Index {
+	idx := sitemap.Index{}
+	for i := range parts {
+		idx.Sitemaps = append(idx.Sitemaps, sitemap.IndexEntry{
+			Loc:     fmt.Sprintf("%s/sitemap-%d.xml", baseURL, i+1),
+			LastMod: lastMod.Format(time.RFC3339),
+		})
+	}
+	return idx
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"myBolg/internal/sitemap"
+)
+
+func TestSplitSitemapURLsUnderLimit(t *testing.T) {
+	urls := []sitemap.URL{{Loc: "http://example.com/a"}, {Loc: "http://example.com/b"}}
+	sets, err := splitSitemapURLs(urls)
+	if err != nil {
+		t.Fatalf("splitSitemapURLs: %v", err)
+	}
+	if len(sets) != 1 {
+		t.Fatalf("len(sets) = %d, want 1", len(sets))
+	}
+	if len(sets[0].URLs) != 2 {
+		t.Fatalf("len(sets[0].URLs) = %d, want 2", len(sets[0].URLs))
+	}
+}
+
+func TestSplitSitemapURLsOverURLCount(t *testing.T) {
+	urls := make([]sitemap.URL, maxSitemapURLs+1)
+	for i := range urls {
+		urls[i] = sitemap.URL{Loc: "http://example.com/post"}
+	}
+	sets, err := splitSitemapURLs(urls)
+	if err != nil {
+		t.Fatalf("splitSitemapURLs: %v", err)
+	}
+	if len(sets) != 2 {
+		t.Fatalf("len(sets) = %d, want 2", len(sets))
+	}
+	if len(sets[0].URLs) != maxSitemapURLs || len(sets[1].URLs) != 1 {
+		t.Fatalf("got %d/%d urls, want %d/1", len(sets[0].URLs), len(sets[1].URLs), maxSitemapURLs)
+	}
+}
+
+func TestFitSitemapChunkHalvesOversizedChunk(t *testing.T) {
+	// Each Priority is maxSitemapBytes/2 bytes long, so with the surrounding
+	// markup a single URLSet holding both exceeds maxSitemapBytes and must
+	// be split down to one URL per set.
+	big := strings.Repeat("0", maxSitemapBytes/2)
+	chunk := []sitemap.URL{
+		{Loc: "http://example.com/a", Priority: big},
+		{Loc: "http://example.com/b", Priority: big},
+	}
+
+	sets, err := fitSitemapChunk(chunk)
+	if err != nil {
+		t.Fatalf("fitSitemapChunk: %v", err)
+	}
+	if len(sets) != len(chunk) {
+		t.Fatalf("len(sets) = %d, want %d (one URL per set)", len(sets), len(chunk))
+	}
+	for _, s := range sets {
+		if len(s.URLs) != 1 {
+			t.Errorf("set has %d urls, want 1", len(s.URLs))
+		}
+	}
+}
+
+func TestSitemapURLsSlugifiesTagLocs(t *testing.T) {
+	posts := []post{{Slug: "hello", Tags: []string{"Go Lang"}}}
+	urls := sitemapURLs(posts, []string{"Go Lang"}, "http://example.com")
+
+	for _, u := range urls {
+		if strings.Contains(u.Loc, " ") {
+			t.Errorf("loc %q contains a literal space", u.Loc)
+		}
+	}
+
+	var sawTagLoc bool
+	for _, u := range urls {
+		if u.Loc == "http://example.com/tags/go-lang/" {
+			sawTagLoc = true
+		}
+	}
+	if !sawTagLoc {
+		t.Errorf("expected a slugified tag loc, got %+v", urls)
+	}
+}
+
+func TestSitemapURLsPercentEncodesNonASCIITagLocs(t *testing.T) {
+	posts := []post{{Slug: "hello", Tags: []string{"博客"}}}
+	urls := sitemapURLs(posts, []string{"博客"}, "http://example.com")
+
+	for _, u := range urls {
+		for _, r := range u.Loc {
+			if r > 0x7f {
+				t.Errorf("loc %q contains a raw non-ASCII character", u.Loc)
+			}
+		}
+	}
+
+	var sawTagLoc bool
+	for _, u := range urls {
+		if u.Loc == "http://example.com/tags/%E5%8D%9A%E5%AE%A2/" {
+			sawTagLoc = true
+		}
+	}
+	if !sawTagLoc {
+		t.Errorf("expected a percent-encoded tag loc, got %+v", urls)
+	}
+}
+
+func TestSitemapURLsSkipsEmptySlugTags(t *testing.T) {
+	posts := []post{{Slug: "hello", Tags: []string{"!!!"}}}
+	urls := sitemapURLs(posts, []string{"!!!"}, "http://example.com")
+
+	for _, u := range urls {
+		if strings.HasSuffix(u.Loc, "/tags//") {
+			t.Errorf("got an empty-slug tag loc: %q", u.Loc)
+		}
+	}
+}
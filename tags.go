@@ -0,0 +1,212 @@
+package main
+
+import (
+	"html/template"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// tagIndex groups posts by tag, built once per request from the already
+// loaded post list.
+func tagIndex(posts []post) map[string][]post {
+	idx := make(map[string][]post)
+	for _, p := range posts {
+		for _, tag := range p.Tags {
+			idx[tag] = append(idx[tag], p)
+		}
+	}
+	return idx
+}
+
+// sortedTags returns idx's keys in a stable, alphabetical order.
+func sortedTags(idx map[string][]post) []string {
+	tags := make([]string, 0, len(idx))
+	for t := range idx {
+		tags = append(tags, t)
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// tagSlug turns a tag into the URL- and filesystem-safe segment used for
+// its "/tags/<slug>/" page: lowercased, with runs of whitespace and
+// punctuation collapsed to a single hyphen. Letters and numbers outside
+// ASCII (e.g. CJK tags) are kept as-is so non-Latin tags still slugify to
+// something other than an empty string.
+func tagSlug(tag string) string {
+	var sb strings.Builder
+	dash := true // true right after a hyphen (or at the start), to avoid doubling up
+	for _, r := range strings.ToLower(tag) {
+		if unicode.IsLetter(r) || unicode.IsNumber(r) {
+			sb.WriteRune(r)
+			dash = false
+			continue
+		}
+		if !dash {
+			sb.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.Trim(sb.String(), "-")
+}
+
+// lookupTagSlug finds the original tag in idx whose slug matches slug,
+// since idx is keyed by the tag's display text rather than its slug.
+func lookupTagSlug(idx map[string][]post, slug string) (tag string, posts []post, ok bool) {
+	for t, posts := range idx {
+		if tagSlug(t) == slug {
+			return t, posts, true
+		}
+	}
+	return "", nil, false
+}
+
+// tagChips renders the tag links shown under a post's title or summary.
+func tagChips(tags []string) template.HTML {
+	if len(tags) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString("<div class=\"post-tags\">")
+	for _, tag := range tags {
+		slug := tagSlug(tag)
+		if slug == "" {
+			continue
+		}
+		sb.WriteString("<a class=\"tag-chip\" href=\"/tags/")
+		sb.WriteString(slug)
+		sb.WriteString("/\">")
+		sb.WriteString(template.HTMLEscapeString(tag))
+		sb.WriteString("</a>")
+	}
+	sb.WriteString("</div>")
+	return template.HTML(sb.String())
+}
+
+// handleTags routes both the tags index ("/tags/") and individual tag
+// pages ("/tags/<slug>/").
+func (s *Site) handleTags(w http.ResponseWriter, r *http.Request) {
+	slug := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/tags/"), "/")
+	if slug == "" {
+		s.handleTagsIndex(w, r)
+		return
+	}
+	if strings.Contains(slug, "/") {
+		http.NotFound(w, r)
+		return
+	}
+	s.handleTagPage(w, r, slug)
+}
+
+func (s *Site) handleTagsIndex(w http.ResponseWriter, r *http.Request) {
+	posts, err := s.Posts()
+	if err != nil {
+		http.Error(w, "读取文章失败", http.StatusInternalServerError)
+		return
+	}
+	idx := tagIndex(posts)
+
+	var sb strings.Builder
+	sb.WriteString("<h1>标签</h1><ul class=\"post-list\">")
+	for _, tag := range sortedTags(idx) {
+		slug := tagSlug(tag)
+		if slug == "" {
+			continue
+		}
+		sb.WriteString("<li><a href=\"/tags/")
+		sb.WriteString(slug)
+		sb.WriteString("/\">")
+		sb.WriteString(template.HTMLEscapeString(tag))
+		sb.WriteString("</a> (")
+		sb.WriteString(strconv.Itoa(len(idx[tag])))
+		sb.WriteString(")</li>")
+	}
+	sb.WriteString("</ul>")
+
+	page, err := renderPage("标签", template.HTML(sb.String()))
+	if err != nil {
+		http.Error(w, "渲染失败", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = w.Write(page)
+}
+
+// buildTagPages writes the tags index and each per-tag page into outDir,
+// mirroring handleTagsIndex/handleTagPage for the static builder.
+func buildTagPages(idx map[string][]post, tags []string, outDir string) error {
+	var sb strings.Builder
+	sb.WriteString("<h1>标签</h1><ul class=\"post-list\">")
+	for _, tag := range tags {
+		slug := tagSlug(tag)
+		if slug == "" {
+			continue
+		}
+		sb.WriteString("<li><a href=\"/tags/")
+		sb.WriteString(slug)
+		sb.WriteString("/\">")
+		sb.WriteString(template.HTMLEscapeString(tag))
+		sb.WriteString("</a> (")
+		sb.WriteString(strconv.Itoa(len(idx[tag])))
+		sb.WriteString(")</li>")
+	}
+	sb.WriteString("</ul>")
+
+	indexPage, err := renderPage("标签", template.HTML(sb.String()))
+	if err != nil {
+		return err
+	}
+	tagsDir := filepath.Join(outDir, "tags")
+	if err := os.MkdirAll(tagsDir, 0o755); err != nil {
+		return err
+	}
+	if err := writeFile(filepath.Join(tagsDir, "index.html"), indexPage); err != nil {
+		return err
+	}
+
+	for _, tag := range tags {
+		slug := tagSlug(tag)
+		if slug == "" {
+			continue
+		}
+		page, err := renderPage(tag, renderIndexContent(idx[tag]))
+		if err != nil {
+			return err
+		}
+		dir := filepath.Join(tagsDir, slug)
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+		if err := writeFile(filepath.Join(dir, "index.html"), page); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Site) handleTagPage(w http.ResponseWriter, r *http.Request, slug string) {
+	posts, err := s.Posts()
+	if err != nil {
+		http.Error(w, "读取文章失败", http.StatusInternalServerError)
+		return
+	}
+	tag, tagged, ok := lookupTagSlug(tagIndex(posts), slug)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	page, err := renderPage(tag, renderIndexContent(tagged))
+	if err != nil {
+		http.Error(w, "渲染失败", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = w.Write(page)
+}